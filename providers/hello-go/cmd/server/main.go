@@ -1,42 +1,110 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"path/filepath"
 
-	pb "github.com/forgequant/mcp-trader/providers/hello-go/internal/pb"
-	"github.com/forgequant/mcp-trader/providers/hello-go/internal/server"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/observability"
+	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/server"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
 	"google.golang.org/grpc"
 )
 
+// parseBackpressurePolicy validates the --stream-backpressure flag value
+// against the policies streaming.BackpressurePolicy actually supports,
+// rather than letting an unrecognized string reach NewProviderServer and
+// silently fall through to a subscriber default.
+func parseBackpressurePolicy(value string) (streaming.BackpressurePolicy, error) {
+	switch policy := streaming.BackpressurePolicy(value); policy {
+	case streaming.DropOldest, streaming.Block, streaming.CoalesceByKey:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid --stream-backpressure %q: must be one of %q, %q, %q",
+			value, streaming.DropOldest, streaming.Block, streaming.CoalesceByKey)
+	}
+}
+
+// toolConfigFile is the shape of the --tool-config file: a list of Go
+// plugin .so files to load and, per tool name, the JSON configuration
+// block passed to that tool's factory Constructor.
+type toolConfigFile struct {
+	Plugins []string                   `json:"plugins"`
+	Tools   map[string]json.RawMessage `json:"tools"`
+}
+
+func loadToolConfig(path string) (toolConfigFile, error) {
+	var cfg toolConfigFile
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read tool config %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid tool config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
 func main() {
 	port := flag.Int("port", 50051, "gRPC server port")
-	schemaDir := flag.String("schema-dir", "../../pkg/schemas", "Path to JSON schema directory")
+	toolConfigPath := flag.String("tool-config", "", "Path to a JSON file listing tool plugins and per-tool configuration blocks")
+	otlpEndpoint := flag.String("otlp-endpoint", "", "OTLP gRPC endpoint to export traces to (e.g. localhost:4317); leave empty to disable export")
+	streamBackpressure := flag.String("stream-backpressure", string(streaming.DropOldest),
+		"Backpressure policy applied to Stream subscribers: drop-oldest, block, or coalesce-by-key")
 	flag.Parse()
 
-	// Resolve schema directory to absolute path
-	absSchemaDir, err := filepath.Abs(*schemaDir)
+	ctx := context.Background()
+
+	backpressurePolicy, err := parseBackpressurePolicy(*streamBackpressure)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger := observability.NewLogger()
+
+	tracer, shutdownTracing, err := observability.InitTracerProvider(ctx, *otlpEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Failed to shut down tracer provider", "error", err)
+		}
+	}()
+
+	toolConfig, err := loadToolConfig(*toolConfigPath)
 	if err != nil {
-		log.Fatalf("Failed to resolve schema directory: %v", err)
+		log.Fatalf("Failed to load tool config: %v", err)
 	}
 
-	// Check if schema directory exists
-	if _, err := os.Stat(absSchemaDir); os.IsNotExist(err) {
-		log.Fatalf("Schema directory does not exist: %s", absSchemaDir)
+	if err := tools.LoadPlugins(toolConfig.Plugins); err != nil {
+		log.Fatalf("Failed to load tool plugins: %v", err)
 	}
 
 	// Create provider server
-	providerServer, err := server.NewProviderServer(absSchemaDir)
+	providerServer, err := server.NewProviderServer(toolConfig.Tools,
+		server.WithStreamBackpressurePolicy(backpressurePolicy))
 	if err != nil {
 		log.Fatalf("Failed to create provider server: %v", err)
 	}
 
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(observability.UnaryServerInterceptor(tracer, logger)),
+		grpc.ChainStreamInterceptor(observability.StreamServerInterceptor(tracer, logger)),
+	)
 	pb.RegisterProviderServer(grpcServer, providerServer)
 
 	// Start listening
@@ -46,8 +114,7 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", address, err)
 	}
 
-	log.Printf("hello-go provider listening on %s", address)
-	log.Printf("Schema directory: %s", absSchemaDir)
+	logger.Info("hello-go provider listening", "address", address)
 
 	if err := grpcServer.Serve(listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
@@ -1,59 +1,42 @@
 package capabilities
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
-
-	pb "github.com/forgequant/mcp-trader/providers/hello-go/internal/pb"
+	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
 )
 
 const ProviderVersion = "0.1.0"
 
-// Builder constructs provider capabilities from schema files
+// Builder constructs provider capabilities from the tools registered in
+// the tools package.
 type Builder struct {
-	schemaDir string
+	factories []tools.ToolFactory
 }
 
-// NewBuilder creates a new capabilities builder
-func NewBuilder(schemaDir string) *Builder {
+// NewBuilder creates a new capabilities builder over factories. Pass
+// tools.Factories() for the full set of tools the provider currently has
+// registered.
+func NewBuilder(factories []tools.ToolFactory) *Builder {
 	return &Builder{
-		schemaDir: schemaDir,
+		factories: factories,
 	}
 }
 
 // Build constructs the Capabilities response
 func (b *Builder) Build() (*pb.Capabilities, error) {
-	// Load echo tool schema
-	echoSchema, err := b.loadSchema("echo.input.schema.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load echo schema: %w", err)
-	}
-
-	// Load sum tool schema
-	sumSchema, err := b.loadSchema("sum.input.schema.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load sum schema: %w", err)
+	toolDefs := make([]*pb.Tool, 0, len(b.factories))
+	for _, f := range b.factories {
+		toolDefs = append(toolDefs, &pb.Tool{
+			Name:        f.Name(),
+			Description: f.Description(),
+			InputSchema: &pb.Json{
+				Value: f.InputSchema(),
+			},
+		})
 	}
 
 	capabilities := &pb.Capabilities{
-		Tools: []*pb.Tool{
-			{
-				Name:        "echo.v1",
-				Description: "Echoes back the provided message",
-				InputSchema: &pb.Json{
-					Value: echoSchema,
-				},
-			},
-			{
-				Name:        "sum.v1",
-				Description: "Calculates the sum of an array of numbers",
-				InputSchema: &pb.Json{
-					Value: sumSchema,
-				},
-			},
-		},
+		Tools:           toolDefs,
 		Resources:       []*pb.Resource{},
 		Prompts:         []*pb.Prompt{},
 		ProviderVersion: ProviderVersion,
@@ -61,20 +44,3 @@ func (b *Builder) Build() (*pb.Capabilities, error) {
 
 	return capabilities, nil
 }
-
-// loadSchema reads a JSON schema file and returns it as bytes
-func (b *Builder) loadSchema(filename string) ([]byte, error) {
-	path := filepath.Join(b.schemaDir, filename)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read schema file %s: %w", path, err)
-	}
-
-	// Validate it's valid JSON
-	var schemaObj map[string]any
-	if err := json.Unmarshal(data, &schemaObj); err != nil {
-		return nil, fmt.Errorf("invalid JSON in schema file %s: %w", path, err)
-	}
-
-	return data, nil
-}
@@ -0,0 +1,167 @@
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// correlationIDProvider is implemented by every request message that
+// carries a correlation_id field.
+type correlationIDProvider interface {
+	GetCorrelationId() string
+}
+
+// toolNamed is implemented by request messages that target a specific
+// tool; currently only *pb.InvokeRequest.
+type toolNamed interface {
+	GetToolName() string
+}
+
+// newCorrelationID generates a ULID to use when a request doesn't supply
+// its own correlation_id.
+func newCorrelationID() string {
+	return ulid.Make().String()
+}
+
+func correlationIDOf(req any) string {
+	if cp, ok := req.(correlationIDProvider); ok {
+		if id := cp.GetCorrelationId(); id != "" {
+			return id
+		}
+	}
+	return newCorrelationID()
+}
+
+func methodName(fullMethod string) string {
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[i+1:]
+	}
+	return fullMethod
+}
+
+// spanNameFor builds the provider.<Method>[/<tool>] span name the backlog
+// request calls for; the /<tool> suffix is only meaningful for Invoke.
+func spanNameFor(fullMethod string, req any) string {
+	name := "provider." + methodName(fullMethod)
+	if tn, ok := req.(toolNamed); ok && tn.GetToolName() != "" {
+		name += "/" + tn.GetToolName()
+	}
+	return name
+}
+
+func payloadBytesOf(req any) int {
+	if invoke, ok := req.(*pb.InvokeRequest); ok && invoke.Payload != nil {
+		return len(invoke.Payload.Value)
+	}
+	return 0
+}
+
+// UnaryServerInterceptor extracts (or generates) a correlation ID for
+// every unary RPC, starts a span named provider.<Method>[/<tool>]
+// recording tool.name, payload.bytes and the call's error status, and
+// makes a correlation- and trace-tagged structured logger available to
+// the handler and to tools (via SlogFromCtx) through the context.
+func UnaryServerInterceptor(tracer trace.Tracer, baseLogger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		correlationID := correlationIDOf(req)
+
+		ctx, span := tracer.Start(ctx, spanNameFor(info.FullMethod, req))
+		defer span.End()
+
+		span.SetAttributes(
+			attribute.String("correlation_id", correlationID),
+			attribute.Int("payload.bytes", payloadBytesOf(req)),
+		)
+		if tn, ok := req.(toolNamed); ok && tn.GetToolName() != "" {
+			span.SetAttributes(attribute.String("tool.name", tn.GetToolName()))
+		}
+
+		logger := loggerForSpan(baseLogger, correlationID, span.SpanContext())
+		ctx = WithLogger(WithCorrelationID(ctx, correlationID), logger)
+
+		resp, err := handler(ctx, req)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logger.Error("unary call failed", "method", info.FullMethod, "error", err)
+		} else {
+			span.SetStatus(codes.Ok, "")
+			logger.Info("unary call completed", "method", info.FullMethod)
+		}
+
+		return resp, err
+	}
+}
+
+// wrappedServerStream lets the stream interceptor upgrade the
+// placeholder correlation ID to the one carried by the first request
+// message, retroactively tagging the span and logger once RecvMsg
+// observes it.
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	span     trace.Span
+	base     *slog.Logger
+	logger   *slog.Logger
+	captured bool
+}
+
+func (w *wrappedServerStream) Context() context.Context { return w.ctx }
+
+func (w *wrappedServerStream) RecvMsg(m any) error {
+	err := w.ServerStream.RecvMsg(m)
+	if err == nil && !w.captured {
+		w.captured = true
+		if cp, ok := m.(correlationIDProvider); ok {
+			if id := cp.GetCorrelationId(); id != "" {
+				w.span.SetAttributes(attribute.String("correlation_id", id))
+				w.logger = loggerForSpan(w.base, id, w.span.SpanContext())
+				w.ctx = WithLogger(WithCorrelationID(w.ctx, id), w.logger)
+			}
+		}
+	}
+	return err
+}
+
+// StreamServerInterceptor is the streaming counterpart to
+// UnaryServerInterceptor. The generated handler decodes the request
+// message itself, so the correlation ID starts as a generated ULID and is
+// upgraded in place, via wrappedServerStream.RecvMsg, the moment the real
+// request arrives.
+func StreamServerInterceptor(tracer trace.Tracer, baseLogger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		correlationID := newCorrelationID()
+
+		ctx, span := tracer.Start(ss.Context(), "provider."+methodName(info.FullMethod))
+		defer span.End()
+
+		span.SetAttributes(attribute.String("correlation_id", correlationID))
+
+		logger := loggerForSpan(baseLogger, correlationID, span.SpanContext())
+		ctx = WithLogger(WithCorrelationID(ctx, correlationID), logger)
+
+		ws := &wrappedServerStream{ServerStream: ss, ctx: ctx, span: span, base: baseLogger, logger: logger}
+
+		err := handler(srv, ws)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			ws.logger.Error("stream call failed", "method", info.FullMethod, "error", err)
+		} else {
+			span.SetStatus(codes.Ok, "")
+			ws.logger.Info("stream call completed", "method", info.FullMethod)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,175 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewCorrelationID_GeneratesDistinctIDs(t *testing.T) {
+	a := newCorrelationID()
+	b := newCorrelationID()
+	if a == "" || b == "" {
+		t.Fatal("expected a non-empty correlation ID")
+	}
+	if a == b {
+		t.Fatal("expected two calls to generate distinct IDs")
+	}
+}
+
+func TestCorrelationIDOf_UsesRequestIDWhenPresent(t *testing.T) {
+	req := &pb.InvokeRequest{CorrelationId: "given-id"}
+	if got := correlationIDOf(req); got != "given-id" {
+		t.Fatalf("expected %q, got %q", "given-id", got)
+	}
+}
+
+func TestCorrelationIDOf_GeneratesWhenMissing(t *testing.T) {
+	req := &pb.InvokeRequest{}
+	if got := correlationIDOf(req); got == "" {
+		t.Fatal("expected a generated correlation ID for a request with none")
+	}
+}
+
+func TestMethodName(t *testing.T) {
+	if got := methodName("/provider.Provider/Invoke"); got != "Invoke" {
+		t.Fatalf("expected %q, got %q", "Invoke", got)
+	}
+}
+
+func TestSpanNameFor_WithAndWithoutTool(t *testing.T) {
+	withTool := spanNameFor("/provider.Provider/Invoke", &pb.InvokeRequest{ToolName: "echo.v1"})
+	if withTool != "provider.Invoke/echo.v1" {
+		t.Fatalf("expected %q, got %q", "provider.Invoke/echo.v1", withTool)
+	}
+
+	withoutTool := spanNameFor("/provider.Provider/ListCapabilities", &pb.InvokeRequest{})
+	if withoutTool != "provider.ListCapabilities" {
+		t.Fatalf("expected %q, got %q", "provider.ListCapabilities", withoutTool)
+	}
+}
+
+func TestPayloadBytesOf(t *testing.T) {
+	req := &pb.InvokeRequest{Payload: &pb.Json{Value: []byte("12345")}}
+	if got := payloadBytesOf(req); got != 5 {
+		t.Fatalf("expected 5, got %d", got)
+	}
+
+	if got := payloadBytesOf(&pb.InvokeRequest{}); got != 0 {
+		t.Fatalf("expected 0 for a nil payload, got %d", got)
+	}
+}
+
+func TestUnaryServerInterceptor_PropagatesCorrelationIDAndError(t *testing.T) {
+	interceptor := UnaryServerInterceptor(otel.Tracer("test"), NewLogger())
+
+	wantErr := errors.New("handler failed")
+	var gotCorrelationID string
+	handler := func(ctx context.Context, req any) (any, error) {
+		gotCorrelationID = CorrelationIDFromContext(ctx)
+		if SlogFromCtx(ctx) == nil {
+			t.Fatal("expected a non-nil logger in the handler's context")
+		}
+		return nil, wantErr
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/provider.Provider/Invoke"}
+	req := &pb.InvokeRequest{ToolName: "echo.v1", CorrelationId: "req-id"}
+
+	_, err := interceptor(context.Background(), req, info, handler)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the handler's error to propagate, got %v", err)
+	}
+	if gotCorrelationID != "req-id" {
+		t.Fatalf("expected the request's correlation ID %q to reach the handler, got %q", "req-id", gotCorrelationID)
+	}
+}
+
+func TestUnaryServerInterceptor_GeneratesCorrelationIDWhenMissing(t *testing.T) {
+	interceptor := UnaryServerInterceptor(otel.Tracer("test"), NewLogger())
+
+	var gotCorrelationID string
+	handler := func(ctx context.Context, req any) (any, error) {
+		gotCorrelationID = CorrelationIDFromContext(ctx)
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/provider.Provider/ListCapabilities"}
+	if _, err := interceptor(context.Background(), &pb.InvokeRequest{}, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotCorrelationID == "" {
+		t.Fatal("expected a generated correlation ID to reach the handler")
+	}
+}
+
+// fakeServerStream is a minimal grpc.ServerStream that replays a fixed
+// sequence of received messages, for exercising StreamServerInterceptor
+// and wrappedServerStream.RecvMsg without a real gRPC connection.
+type fakeServerStream struct {
+	ctx  context.Context
+	msgs []*pb.StreamRequest
+}
+
+func (f *fakeServerStream) SetHeader(metadata.MD) error  { return nil }
+func (f *fakeServerStream) SendHeader(metadata.MD) error { return nil }
+func (f *fakeServerStream) SetTrailer(metadata.MD)       {}
+func (f *fakeServerStream) Context() context.Context     { return f.ctx }
+func (f *fakeServerStream) SendMsg(m any) error           { return nil }
+
+func (f *fakeServerStream) RecvMsg(m any) error {
+	if len(f.msgs) == 0 {
+		return io.EOF
+	}
+	next := f.msgs[0]
+	f.msgs = f.msgs[1:]
+	dst, ok := m.(*pb.StreamRequest)
+	if !ok {
+		return errors.New("unexpected message type")
+	}
+	*dst = *next
+	return nil
+}
+
+func TestStreamServerInterceptor_UpgradesCorrelationIDOnFirstRecv(t *testing.T) {
+	interceptor := StreamServerInterceptor(otel.Tracer("test"), NewLogger())
+
+	stream := &fakeServerStream{
+		ctx:  context.Background(),
+		msgs: []*pb.StreamRequest{{Topic: "ticker.v1/abc", CorrelationId: "upgraded-id"}},
+	}
+
+	var idBeforeRecv, idAfterRecv string
+	handler := func(srv any, ss grpc.ServerStream) error {
+		idBeforeRecv = CorrelationIDFromContext(ss.Context())
+
+		var req pb.StreamRequest
+		if err := ss.RecvMsg(&req); err != nil {
+			return err
+		}
+
+		idAfterRecv = CorrelationIDFromContext(ss.Context())
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/provider.Provider/Stream"}
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if idBeforeRecv == "" {
+		t.Fatal("expected a placeholder correlation ID before the first RecvMsg")
+	}
+	if idAfterRecv != "upgraded-id" {
+		t.Fatalf("expected RecvMsg to upgrade the correlation ID to %q, got %q", "upgraded-id", idAfterRecv)
+	}
+	if idBeforeRecv == idAfterRecv {
+		t.Fatal("expected the correlation ID to change once the real request was received")
+	}
+}
@@ -0,0 +1,65 @@
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewLogger returns the base JSON structured logger every request-scoped
+// logger is derived from. It writes to os.Stdout so log aggregation can
+// treat stdout uniformly across providers.
+func NewLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+}
+
+type loggerCtxKey struct{}
+
+type correlationIDCtxKey struct{}
+
+// WithLogger returns a context carrying logger, retrievable with
+// SlogFromCtx.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// SlogFromCtx returns the request-scoped logger the gRPC interceptors
+// stashed in ctx, already tagged with correlation_id and, once a trace is
+// active, trace_id/span_id. Tool authors should log through this instead
+// of slog.Default() so their log lines correlate with the request that
+// produced them. Falls back to slog.Default() when called outside a
+// request, e.g. in a unit test.
+func SlogFromCtx(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerCtxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithCorrelationID returns a context carrying id, retrievable with
+// CorrelationIDFromContext.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDCtxKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID the gRPC
+// interceptors stashed in ctx, or "" if there isn't one.
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDCtxKey{}).(string)
+	return id
+}
+
+// loggerForSpan tags base with id and, once sc carries a live trace, with
+// trace_id/span_id, so log lines can be pivoted to from a trace view.
+func loggerForSpan(base *slog.Logger, id string, sc trace.SpanContext) *slog.Logger {
+	logger := base.With("correlation_id", id)
+	if sc.HasTraceID() {
+		logger = logger.With("trace_id", sc.TraceID().String())
+	}
+	if sc.HasSpanID() {
+		logger = logger.With("span_id", sc.SpanID().String())
+	}
+	return logger
+}
@@ -0,0 +1,48 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/forgetrade/mcp-trader/providers/hello-go"
+
+// InitTracerProvider configures tracing for the provider and returns the
+// Tracer the gRPC interceptors should use plus a shutdown func to flush
+// and release it on exit. When otlpEndpoint is empty, spans are still
+// created (so span/trace IDs keep showing up in logs) but nothing is
+// exported, which is the no-op default that lets the rest of the
+// provider run unmodified with tracing disabled.
+func InitTracerProvider(ctx context.Context, otlpEndpoint string) (trace.Tracer, func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceNameKey.String("hello-go"),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if otlpEndpoint != "" {
+		exporter, err := otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(otlpEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP exporter for %s: %w", otlpEndpoint, err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	return tp.Tracer(tracerName), tp.Shutdown, nil
+}
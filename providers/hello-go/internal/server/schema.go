@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// SchemaValidationError reports why a payload failed JSON Schema
+// validation, pinpointing where in the document the failure occurred.
+type SchemaValidationError struct {
+	// Pointer is the JSON Pointer (e.g. "/numbers/0") to the value that
+	// failed validation.
+	Pointer string
+	// Keyword is the schema keyword that rejected the value, e.g.
+	// "required", "type", or "minimum".
+	Keyword string
+	// Message is a human-readable description of the failure.
+	Message string
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("schema validation failed at %q (keyword %q): %s", e.Pointer, e.Keyword, e.Message)
+}
+
+// compileSchema compiles raw JSON Schema bytes into a reusable validator.
+// name is used only as the in-memory resource URL the compiler registers
+// the schema under, so it just needs to be unique per tool. It must not
+// contain a "#": AddResource treats anything after a "#" as a fragment and
+// panics if one is present in the resource URL itself, so we reject that
+// case up front instead of letting a bad tool name take down the process.
+func compileSchema(name string, data []byte) (*jsonschema.Schema, error) {
+	if strings.Contains(name, "#") {
+		return nil, fmt.Errorf("schema resource name %q must not contain '#'", name)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(name, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to add schema resource %s: %w", name, err)
+	}
+
+	schema, err := compiler.Compile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s: %w", name, err)
+	}
+
+	return schema, nil
+}
+
+// validateAgainstSchema validates raw JSON payload bytes against a compiled
+// schema, returning a *SchemaValidationError describing the first failure
+// found. A nil schema always validates successfully.
+func validateAgainstSchema(schema *jsonschema.Schema, payload []byte) error {
+	if schema == nil {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return fmt.Errorf("invalid JSON payload: %w", err)
+	}
+
+	if err := schema.Validate(v); err != nil {
+		if ve, ok := err.(*jsonschema.ValidationError); ok {
+			return validationErrorToSchemaError(ve)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// validationErrorToSchemaError walks to the deepest (most specific) cause
+// of a jsonschema.ValidationError and converts it into our own error type,
+// so callers get the precise failing JSON Pointer and keyword rather than
+// the library's nested, human-oriented tree.
+func validationErrorToSchemaError(ve *jsonschema.ValidationError) *SchemaValidationError {
+	leaf := ve
+	for len(leaf.Causes) > 0 {
+		leaf = leaf.Causes[0]
+	}
+
+	return &SchemaValidationError{
+		Pointer: leaf.InstanceLocation,
+		Keyword: keywordFromLocation(leaf.KeywordLocation),
+		Message: leaf.Message,
+	}
+}
+
+// keywordFromLocation extracts the trailing schema keyword (e.g.
+// "required", "minimum") from a jsonschema keyword location such as
+// "/properties/numbers/minItems".
+func keywordFromLocation(location string) string {
+	for i := len(location) - 1; i >= 0; i-- {
+		if location[i] == '/' {
+			return location[i+1:]
+		}
+	}
+	return location
+}
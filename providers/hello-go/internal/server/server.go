@@ -4,73 +4,171 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 
 	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/capabilities"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/observability"
 	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
 	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// Tool defines the interface all tools must implement
-type Tool interface {
-	Name() string
-	Description() string
-	Invoke(payload []byte) ([]byte, error)
+// Tool defines the interface all tools must implement. It is an alias for
+// tools.Tool so the registry and plugin factories in the tools package can
+// return tools the server accepts without the two packages importing each
+// other.
+type Tool = tools.Tool
+
+// ValidationBypasser is an optional interface a Tool can implement to skip
+// output validation entirely, for tools that deliberately return a
+// dynamic, schema-less shape.
+type ValidationBypasser interface {
+	BypassOutputValidation() bool
 }
 
+// toolEntry pairs a registered Tool with the compiled validators for its
+// input and (optionally) output payloads.
+type toolEntry struct {
+	tool            Tool
+	inputSchema     *jsonschema.Schema
+	outputSchema    *jsonschema.Schema
+	bypassOutputVal bool
+}
+
+// defaultStreamBackpressure is the policy applied to Stream subscribers
+// when no Option overrides it.
+const defaultStreamBackpressure = streaming.DropOldest
+
 // ProviderServer implements the Provider gRPC service
 type ProviderServer struct {
 	pb.UnimplementedProviderServer
 	capabilities *pb.Capabilities
-	tools        map[string]Tool
+	tools        map[string]*toolEntry
+	broker       *streaming.Broker
+	streamPolicy streaming.BackpressurePolicy
+}
+
+// Option configures optional ProviderServer behavior.
+type Option func(*ProviderServer)
+
+// WithStreamBackpressurePolicy overrides the backpressure policy applied
+// to every Stream subscriber. The default is streaming.DropOldest.
+func WithStreamBackpressurePolicy(policy streaming.BackpressurePolicy) Option {
+	return func(s *ProviderServer) {
+		s.streamPolicy = policy
+	}
 }
 
-// NewProviderServer creates a new provider server
-func NewProviderServer(schemaDir string) (*ProviderServer, error) {
-	// Build capabilities from schema files
-	builder := capabilities.NewBuilder(schemaDir)
+// NewProviderServer creates a new provider server from every tool
+// currently registered in the tools package (built-ins plus any plugins
+// the caller has already loaded via tools.LoadPlugins). toolConfig
+// supplies the per-tool JSON configuration block, keyed by tool name, that
+// each factory's Constructor receives; a tool with no entry gets an empty
+// object.
+func NewProviderServer(toolConfig map[string]json.RawMessage, opts ...Option) (*ProviderServer, error) {
+	factories := tools.Factories()
+
+	// Build capabilities from the registered factories' declared schemas
+	builder := capabilities.NewBuilder(factories)
 	caps, err := builder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build capabilities: %w", err)
 	}
 
-	// Register tools
-	toolRegistry := make(map[string]Tool)
-	echoTool := &tools.Echo{}
-	sumTool := &tools.Sum{}
-
-	toolRegistry[echoTool.Name()] = echoTool
-	toolRegistry[sumTool.Name()] = sumTool
+	toolRegistry := make(map[string]*toolEntry, len(factories))
+	for _, f := range factories {
+		cfg, ok := toolConfig[f.Name()]
+		if !ok {
+			cfg = json.RawMessage("{}")
+		}
+
+		tool, err := f.Constructor()(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct tool %s: %w", f.Name(), err)
+		}
+
+		entry := &toolEntry{tool: tool}
+
+		if raw := f.InputSchema(); len(raw) > 0 {
+			schema, err := compileSchema(f.Name()+"-input", raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile input schema for %s: %w", f.Name(), err)
+			}
+			entry.inputSchema = schema
+		}
+
+		if raw := f.OutputSchema(); len(raw) > 0 {
+			schema, err := compileSchema(f.Name()+"-output", raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile output schema for %s: %w", f.Name(), err)
+			}
+			entry.outputSchema = schema
+		}
+
+		if bypasser, ok := tool.(ValidationBypasser); ok {
+			entry.bypassOutputVal = bypasser.BypassOutputValidation()
+		}
+
+		toolRegistry[f.Name()] = entry
+	}
 
-	return &ProviderServer{
+	s := &ProviderServer{
 		capabilities: caps,
 		tools:        toolRegistry,
-	}, nil
+		broker:       streaming.NewBroker(),
+		streamPolicy: defaultStreamBackpressure,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
 // ListCapabilities returns all capabilities exposed by this provider
 func (s *ProviderServer) ListCapabilities(ctx context.Context, req *emptypb.Empty) (*pb.Capabilities, error) {
-	log.Printf("ListCapabilities called")
+	observability.SlogFromCtx(ctx).Info("ListCapabilities called")
 	return s.capabilities, nil
 }
 
 // Invoke executes a tool with the given arguments
 func (s *ProviderServer) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb.InvokeResponse, error) {
-	log.Printf("Invoke called: tool=%s, correlation_id=%s", req.ToolName, req.CorrelationId)
+	logger := observability.SlogFromCtx(ctx).With("tool", req.ToolName)
+	logger.Info("Invoke called")
 
 	// Find the tool
-	tool, exists := s.tools[req.ToolName]
+	entry, exists := s.tools[req.ToolName]
 	if !exists {
 		return &pb.InvokeResponse{
 			Error: fmt.Sprintf("tool not found: %s", req.ToolName),
 		}, nil
 	}
 
+	if req.Payload == nil {
+		return &pb.InvokeResponse{
+			Error: "missing payload",
+		}, nil
+	}
+
+	// Reject the request up front if it doesn't match the tool's declared
+	// input schema, rather than letting malformed input reach Invoke.
+	if err := validateAgainstSchema(entry.inputSchema, req.Payload.Value); err != nil {
+		logger.Warn("Invoke rejected: input validation failed", "error", err)
+		return &pb.InvokeResponse{
+			Error: fmt.Sprintf("input validation failed: %v", err),
+		}, nil
+	}
+
 	// Invoke the tool
-	resultBytes, err := tool.Invoke(req.Payload.Value)
+	tctx := streaming.ToolContext{
+		CorrelationID: req.CorrelationId,
+		Broker:        s.broker,
+	}
+	resultBytes, err := entry.tool.InvokeCtx(ctx, req.Payload.Value, tctx)
 	if err != nil {
-		log.Printf("Tool invocation failed: %v", err)
+		logger.Error("Tool invocation failed", "error", err)
 		return &pb.InvokeResponse{
 			Error: err.Error(),
 		}, nil
@@ -79,12 +177,23 @@ func (s *ProviderServer) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb
 	// Validate the result is valid JSON
 	var resultObj map[string]any
 	if err := json.Unmarshal(resultBytes, &resultObj); err != nil {
-		log.Printf("Tool returned invalid JSON: %v", err)
+		logger.Error("Tool returned invalid JSON", "error", err)
 		return &pb.InvokeResponse{
 			Error: fmt.Sprintf("tool returned invalid JSON: %v", err),
 		}, nil
 	}
 
+	// Catch malformed provider output in tests/CI rather than letting it
+	// reach downstream consumers as an unexpected shape.
+	if !entry.bypassOutputVal {
+		if err := validateAgainstSchema(entry.outputSchema, resultBytes); err != nil {
+			logger.Error("Tool returned output failing its schema", "error", err)
+			return &pb.InvokeResponse{
+				Error: fmt.Sprintf("output validation failed: %v", err),
+			}, nil
+		}
+	}
+
 	return &pb.InvokeResponse{
 		Result: &pb.Json{Value: resultBytes},
 	}, nil
@@ -92,7 +201,7 @@ func (s *ProviderServer) Invoke(ctx context.Context, req *pb.InvokeRequest) (*pb
 
 // ReadResource reads a resource by URI (not implemented for hello-go)
 func (s *ProviderServer) ReadResource(ctx context.Context, req *pb.ResourceRequest) (*pb.ResourceResponse, error) {
-	log.Printf("ReadResource called: uri=%s, correlation_id=%s", req.Uri, req.CorrelationId)
+	observability.SlogFromCtx(ctx).Info("ReadResource called", "uri", req.Uri)
 	return &pb.ResourceResponse{
 		Error: "resources not supported by hello-go provider",
 	}, nil
@@ -100,14 +209,39 @@ func (s *ProviderServer) ReadResource(ctx context.Context, req *pb.ResourceReque
 
 // GetPrompt returns a prompt template (not implemented for hello-go)
 func (s *ProviderServer) GetPrompt(ctx context.Context, req *pb.PromptRequest) (*pb.PromptResponse, error) {
-	log.Printf("GetPrompt called: prompt_name=%s, correlation_id=%s", req.PromptName, req.CorrelationId)
+	observability.SlogFromCtx(ctx).Info("GetPrompt called", "prompt_name", req.PromptName)
 	return &pb.PromptResponse{
 		Error: "prompts not supported by hello-go provider",
 	}, nil
 }
 
-// Stream streams events from provider (not implemented for hello-go)
+// Stream subscribes the caller to req.Topic and forwards the events
+// published to it, as the server's backpressure policy lets them through,
+// until the client disconnects.
 func (s *ProviderServer) Stream(req *pb.StreamRequest, stream pb.Provider_StreamServer) error {
-	log.Printf("Stream called: topic=%s", req.Topic)
-	return fmt.Errorf("streaming not supported by hello-go provider")
+	ctx := stream.Context()
+	observability.SlogFromCtx(ctx).Info("Stream called", "topic", req.Topic)
+
+	sub := s.broker.Subscribe(req.Topic, streaming.SubscriberOptions{
+		Policy:     s.streamPolicy,
+		BufferSize: streaming.DefaultBufferSize,
+	})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&pb.StreamEvent{
+				Topic:   event.Topic,
+				Payload: &pb.Json{Value: event.Payload},
+			}); err != nil {
+				return fmt.Errorf("failed to send stream event: %w", err)
+			}
+		}
+	}
 }
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/forgetrade/mcp-trader/providers/hello-go/internal/pb"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
+)
+
+const (
+	serverTestInputSchema = `{
+		"type": "object",
+		"properties": {"value": {"type": "integer", "minimum": 0}},
+		"required": ["value"]
+	}`
+	serverTestOutputSchema = `{
+		"type": "object",
+		"properties": {"doubled": {"type": "integer"}},
+		"required": ["doubled"]
+	}`
+)
+
+// doublerTool doubles its integer input. When malformed is set it instead
+// returns output that fails serverTestOutputSchema, so callers can
+// exercise output validation and ValidationBypasser.
+type doublerTool struct {
+	malformed bool
+	bypass    bool
+}
+
+func (t *doublerTool) Name() string        { return "" } // unused; registry keys by factory name
+func (t *doublerTool) Description() string { return "" }
+
+func (t *doublerTool) InvokeCtx(ctx context.Context, payload []byte, tctx streaming.ToolContext) ([]byte, error) {
+	if t.malformed {
+		return []byte(`{"doubled": "not-a-number"}`), nil
+	}
+	var in struct {
+		Value int `json:"value"`
+	}
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Doubled int `json:"doubled"`
+	}{Doubled: in.Value * 2})
+}
+
+func (t *doublerTool) BypassOutputValidation() bool { return t.bypass }
+
+func registerDoublerFactory(name string, malformed, bypass bool) {
+	tools.Register(&doublerFactory{name: name, malformed: malformed, bypass: bypass})
+}
+
+type doublerFactory struct {
+	name      string
+	malformed bool
+	bypass    bool
+}
+
+func (f *doublerFactory) Name() string         { return f.name }
+func (f *doublerFactory) Description() string  { return "server package test double" }
+func (f *doublerFactory) InputSchema() []byte  { return []byte(serverTestInputSchema) }
+func (f *doublerFactory) OutputSchema() []byte { return []byte(serverTestOutputSchema) }
+func (f *doublerFactory) Constructor() func(cfg json.RawMessage) (tools.Tool, error) {
+	return func(cfg json.RawMessage) (tools.Tool, error) {
+		return &doublerTool{malformed: f.malformed, bypass: f.bypass}, nil
+	}
+}
+
+func init() {
+	registerDoublerFactory("server-test.valid.v1", false, false)
+	registerDoublerFactory("server-test.malformed.v1", true, false)
+	registerDoublerFactory("server-test.bypass.v1", true, true)
+}
+
+func newTestServer(t *testing.T) *ProviderServer {
+	t.Helper()
+	s, err := NewProviderServer(nil)
+	if err != nil {
+		t.Fatalf("NewProviderServer failed: %v", err)
+	}
+	return s
+}
+
+func TestInvoke_RejectsInputSchemaViolation(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "server-test.valid.v1",
+		Payload:  &pb.Json{Value: []byte(`{}`)},
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if !strings.Contains(resp.Error, "input validation failed") {
+		t.Fatalf("expected an input validation error, got %q", resp.Error)
+	}
+	if !strings.Contains(resp.Error, "required") {
+		t.Fatalf("expected the missing \"value\" field to be reported via the \"required\" keyword, got %q", resp.Error)
+	}
+}
+
+func TestInvoke_AcceptsValidInput(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "server-test.valid.v1",
+		Payload:  &pb.Json{Value: []byte(`{"value": 5}`)},
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if resp.Error != "" {
+		t.Fatalf("expected no error, got %q", resp.Error)
+	}
+
+	var out struct {
+		Doubled int `json:"doubled"`
+	}
+	if err := json.Unmarshal(resp.Result.Value, &out); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	if out.Doubled != 10 {
+		t.Fatalf("expected doubled=10, got %d", out.Doubled)
+	}
+}
+
+func TestInvoke_RejectsMalformedOutput(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "server-test.malformed.v1",
+		Payload:  &pb.Json{Value: []byte(`{"value": 5}`)},
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if !strings.Contains(resp.Error, "output validation failed") {
+		t.Fatalf("expected an output validation error, got %q", resp.Error)
+	}
+}
+
+func TestInvoke_BypassesOutputValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "server-test.bypass.v1",
+		Payload:  &pb.Json{Value: []byte(`{"value": 5}`)},
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if resp.Error != "" {
+		t.Fatalf("expected BypassOutputValidation to suppress the schema failure, got error %q", resp.Error)
+	}
+	if string(resp.Result.Value) != `{"doubled": "not-a-number"}` {
+		t.Fatalf("expected the malformed output to pass through unchanged, got %q", resp.Result.Value)
+	}
+}
+
+func TestInvoke_MissingPayloadIsRejected(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "server-test.valid.v1",
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if resp.Error != "missing payload" {
+		t.Fatalf("expected %q, got %q", "missing payload", resp.Error)
+	}
+}
+
+func TestInvoke_UnknownToolIsRejected(t *testing.T) {
+	s := newTestServer(t)
+
+	resp, err := s.Invoke(context.Background(), &pb.InvokeRequest{
+		ToolName: "does-not-exist.v1",
+		Payload:  &pb.Json{Value: []byte(`{}`)},
+	})
+	if err != nil {
+		t.Fatalf("Invoke returned an error: %v", err)
+	}
+	if !strings.Contains(resp.Error, "tool not found") {
+		t.Fatalf("expected a tool-not-found error, got %q", resp.Error)
+	}
+}
@@ -0,0 +1,135 @@
+// Package streaming provides an in-process pub/sub broker that lets tools
+// publish progress and other events to topics, and lets the Stream RPC
+// subscribe callers to those topics without coupling either side to gRPC.
+package streaming
+
+import "sync"
+
+// BackpressurePolicy controls what a subscriber does when it cannot keep
+// up with the rate events are published on its topic.
+type BackpressurePolicy string
+
+const (
+	// DropOldest discards the oldest buffered event to make room for the
+	// newest one. This is the default policy.
+	DropOldest BackpressurePolicy = "drop-oldest"
+	// Block makes the publisher wait until the subscriber has room,
+	// applying backpressure all the way to the publishing tool.
+	Block BackpressurePolicy = "block"
+	// CoalesceByKey keeps only the most recent event per Event.Key,
+	// collapsing a burst of updates for the same key (e.g. a
+	// correlation ID) into a single pending event.
+	CoalesceByKey BackpressurePolicy = "coalesce-by-key"
+)
+
+// DefaultBufferSize is the subscriber buffer size used when
+// SubscriberOptions.BufferSize is left at zero.
+const DefaultBufferSize = 32
+
+// Event is a single message published to a topic.
+type Event struct {
+	// Topic is the topic the event was published on.
+	Topic string
+	// Key identifies the event for CoalesceByKey subscribers, e.g. a
+	// correlation ID. Ignored by the other policies.
+	Key string
+	// Payload is the event body, typically JSON.
+	Payload []byte
+}
+
+// SubscriberOptions configures how a Subscription buffers events relative
+// to the rate they're published.
+type SubscriberOptions struct {
+	Policy     BackpressurePolicy
+	BufferSize int
+}
+
+// ToolContext is handed to a Tool's InvokeCtx so it can publish events
+// related to the in-flight invocation, e.g. progress on a topic keyed by
+// the request's correlation ID.
+type ToolContext struct {
+	CorrelationID string
+	Broker        *Broker
+}
+
+// Broker is an in-process, topic-based pub/sub hub. The zero value is not
+// usable; construct one with NewBroker.
+type Broker struct {
+	mu     sync.Mutex
+	topics map[string]map[*subscriber]struct{}
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{
+		topics: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe registers a new subscription on topic and returns it. Callers
+// must call Subscription.Close when done to release the subscriber.
+func (b *Broker) Subscribe(topic string, opts SubscriberOptions) *Subscription {
+	sub := newSubscriber(opts)
+
+	b.mu.Lock()
+	subs, ok := b.topics[topic]
+	if !ok {
+		subs = make(map[*subscriber]struct{})
+		b.topics[topic] = subs
+	}
+	subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	events := make(chan Event)
+	go sub.pump(events)
+
+	var once sync.Once
+	return &Subscription{
+		Events: events,
+		close: func() {
+			once.Do(func() {
+				sub.close()
+				b.mu.Lock()
+				if subs, ok := b.topics[topic]; ok {
+					delete(subs, sub)
+					if len(subs) == 0 {
+						delete(b.topics, topic)
+					}
+				}
+				b.mu.Unlock()
+			})
+		},
+	}
+}
+
+// Publish delivers event to every current subscriber of event.Topic,
+// applying each subscriber's own backpressure policy. Publish never
+// blocks on subscribers using DropOldest or CoalesceByKey; it blocks on
+// subscribers using Block until they have room.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	subs := make([]*subscriber, 0, len(b.topics[event.Topic]))
+	for sub := range b.topics[event.Topic] {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.enqueue(event)
+	}
+}
+
+// Subscription is a live subscription to a topic.
+type Subscription struct {
+	// Events delivers published events in order. It is closed once Close
+	// has been called and any buffered events have drained.
+	Events <-chan Event
+
+	close func()
+}
+
+// Close unsubscribes; events already buffered are still delivered on
+// Events before it is closed. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.close()
+}
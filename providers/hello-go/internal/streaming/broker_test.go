@@ -0,0 +1,179 @@
+package streaming_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+)
+
+const testTimeout = 2 * time.Second
+
+func recvEvent(t *testing.T, events <-chan streaming.Event) streaming.Event {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed unexpectedly")
+		}
+		return event
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for event")
+		return streaming.Event{}
+	}
+}
+
+func TestBroker_DropOldest(t *testing.T) {
+	b := streaming.NewBroker()
+	sub := b.Subscribe("topic", streaming.SubscriberOptions{
+		Policy:     streaming.DropOldest,
+		BufferSize: 2,
+	})
+	defer sub.Close()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// The pump takes one event out of the buffer and holds it until it is
+	// read. Hand it a priming event first, so every event of the flood
+	// below lands in the buffer rather than racing through to the pump.
+	b.Publish(streaming.Event{Topic: "topic", Payload: []byte{99}})
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		b.Publish(streaming.Event{Topic: "topic", Payload: []byte{byte(i)}})
+	}
+
+	recvEvent(t, sub.Events) // the priming event held by the pump
+	first := recvEvent(t, sub.Events)
+	second := recvEvent(t, sub.Events)
+
+	if first.Payload[0] != 3 || second.Payload[0] != 4 {
+		t.Fatalf("expected the two newest events (3, 4), got (%d, %d)", first.Payload[0], second.Payload[0])
+	}
+}
+
+func TestBroker_Block(t *testing.T) {
+	b := streaming.NewBroker()
+	sub := b.Subscribe("topic", streaming.SubscriberOptions{
+		Policy:     streaming.Block,
+		BufferSize: 1,
+	})
+	defer sub.Close()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Fill the one-slot buffer; the pump will immediately drain it, so
+	// this much is never expected to block.
+	b.Publish(streaming.Event{Topic: "topic", Payload: []byte{0}})
+
+	published := make(chan struct{})
+	go func() {
+		// This publish can only complete once the first event has been
+		// read off sub.Events, freeing a slot.
+		b.Publish(streaming.Event{Topic: "topic", Payload: []byte{1}})
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("Publish returned before the blocked slot was freed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	recvEvent(t, sub.Events) // frees the slot
+	recvEvent(t, sub.Events) // the previously blocked publish
+
+	select {
+	case <-published:
+	case <-time.After(testTimeout):
+		t.Fatal("blocked Publish never returned after the slot was freed")
+	}
+}
+
+func TestBroker_CoalesceByKey(t *testing.T) {
+	b := streaming.NewBroker()
+	sub := b.Subscribe("topic", streaming.SubscriberOptions{
+		Policy:     streaming.CoalesceByKey,
+		BufferSize: 8,
+	})
+	defer sub.Close()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Prime the pump, as in TestBroker_DropOldest, so the burst below is
+	// coalesced in the buffer instead of its first event reaching the pump.
+	b.Publish(streaming.Event{Topic: "topic", Key: "prime", Payload: []byte{0}})
+	time.Sleep(10 * time.Millisecond)
+
+	b.Publish(streaming.Event{Topic: "topic", Key: "a", Payload: []byte{1}})
+	b.Publish(streaming.Event{Topic: "topic", Key: "a", Payload: []byte{2}})
+	b.Publish(streaming.Event{Topic: "topic", Key: "b", Payload: []byte{3}})
+	b.Publish(streaming.Event{Topic: "topic", Key: "a", Payload: []byte{4}})
+
+	recvEvent(t, sub.Events) // the priming event held by the pump
+	first := recvEvent(t, sub.Events)
+	second := recvEvent(t, sub.Events)
+
+	if first.Key != "a" || first.Payload[0] != 4 {
+		t.Fatalf("expected key a to coalesce to its latest payload (4), got key %q payload %v", first.Key, first.Payload)
+	}
+	if second.Key != "b" || second.Payload[0] != 3 {
+		t.Fatalf("expected key b payload 3, got key %q payload %v", second.Key, second.Payload)
+	}
+}
+
+func TestBroker_CloseDrainsBufferedEvents(t *testing.T) {
+	b := streaming.NewBroker()
+	sub := b.Subscribe("topic", streaming.SubscriberOptions{
+		Policy:     streaming.DropOldest,
+		BufferSize: 4,
+	})
+
+	time.Sleep(10 * time.Millisecond)
+
+	b.Publish(streaming.Event{Topic: "topic", Payload: []byte{1}})
+	sub.Close()
+
+	recvEvent(t, sub.Events)
+
+	select {
+	case _, ok := <-sub.Events:
+		if ok {
+			t.Fatal("expected no further events after the buffer drained")
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Events channel never closed after Close")
+	}
+}
+
+func TestBroker_PublishFansOutToMultipleSubscribers(t *testing.T) {
+	b := streaming.NewBroker()
+	const subscribers = 3
+
+	var wg sync.WaitGroup
+	wg.Add(subscribers)
+	for i := 0; i < subscribers; i++ {
+		sub := b.Subscribe("topic", streaming.SubscriberOptions{Policy: streaming.DropOldest, BufferSize: 4})
+		defer sub.Close()
+		go func() {
+			defer wg.Done()
+			recvEvent(t, sub.Events)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	b.Publish(streaming.Event{Topic: "topic", Payload: []byte{42}})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(testTimeout):
+		t.Fatal("not every subscriber received the published event")
+	}
+}
@@ -0,0 +1,165 @@
+package streaming
+
+import "sync"
+
+// subscriber buffers events for a single Subscription and applies its
+// configured BackpressurePolicy on enqueue. A background goroutine
+// (subscriber.pump) drains the buffer into the Subscription's Events
+// channel in order.
+type subscriber struct {
+	policy   BackpressurePolicy
+	capacity int
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	closed bool
+
+	// queue backs DropOldest and Block.
+	queue []Event
+
+	// inFlight counts an event dequeue has handed to pump but pump has
+	// not yet finished sending on the Events channel. Block counts this
+	// against capacity alongside len(queue), since the event still
+	// occupies a capacity slot until delivered actually observes it sent
+	// — otherwise a slow consumer only ever bounds the publisher to
+	// capacity+1 outstanding events.
+	inFlight int
+
+	// order and byKey back CoalesceByKey: order holds pending keys in
+	// arrival order, byKey holds the latest event for each.
+	order []string
+	byKey map[string]Event
+}
+
+func newSubscriber(opts SubscriberOptions) *subscriber {
+	capacity := opts.BufferSize
+	if capacity <= 0 {
+		capacity = DefaultBufferSize
+	}
+
+	s := &subscriber{
+		policy:   opts.Policy,
+		capacity: capacity,
+		byKey:    make(map[string]Event),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// enqueue applies the subscriber's backpressure policy and buffers event.
+func (s *subscriber) enqueue(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	switch s.policy {
+	case Block:
+		for len(s.queue)+s.inFlight >= s.capacity && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			return
+		}
+		s.queue = append(s.queue, event)
+	case CoalesceByKey:
+		key := event.Key
+		if key == "" {
+			key = event.Topic
+		}
+		if _, pending := s.byKey[key]; !pending {
+			if len(s.order) >= s.capacity {
+				oldest := s.order[0]
+				s.order = s.order[1:]
+				delete(s.byKey, oldest)
+			}
+			s.order = append(s.order, key)
+		}
+		s.byKey[key] = event
+	default: // DropOldest, and the zero value
+		if len(s.queue) >= s.capacity {
+			s.queue = s.queue[1:]
+		}
+		s.queue = append(s.queue, event)
+	}
+
+	s.cond.Signal()
+}
+
+// dequeue blocks until an event is available or the subscriber is closed
+// with an empty buffer, in which case ok is false.
+func (s *subscriber) dequeue() (event Event, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.pendingLocked() == 0 && !s.closed {
+		s.cond.Wait()
+	}
+
+	if s.policy == CoalesceByKey {
+		if len(s.order) == 0 {
+			return Event{}, false
+		}
+		key := s.order[0]
+		s.order = s.order[1:]
+		event = s.byKey[key]
+		delete(s.byKey, key)
+		return event, true
+	}
+
+	if len(s.queue) == 0 {
+		return Event{}, false
+	}
+	event, s.queue = s.queue[0], s.queue[1:]
+	if s.policy == Block {
+		// Still counted against capacity until delivered() runs, so a
+		// blocked Publish can't slip in before pump has actually sent
+		// this event out.
+		s.inFlight++
+	}
+	return event, true
+}
+
+// delivered marks the event most recently returned by dequeue as sent,
+// releasing the capacity slot it was still holding under Block and
+// waking any publisher waiting for room.
+func (s *subscriber) delivered() {
+	s.mu.Lock()
+	if s.inFlight > 0 {
+		s.inFlight--
+	}
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+func (s *subscriber) pendingLocked() int {
+	if s.policy == CoalesceByKey {
+		return len(s.order)
+	}
+	return len(s.queue)
+}
+
+// close marks the subscriber closed and wakes any goroutine blocked in
+// enqueue or dequeue. Buffered events already queued are still delivered.
+func (s *subscriber) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
+
+// pump drains the subscriber into out until it closes and its buffer is
+// empty, then closes out.
+func (s *subscriber) pump(out chan<- Event) {
+	defer close(out)
+	for {
+		event, ok := s.dequeue()
+		if !ok {
+			return
+		}
+		out <- event
+		s.delivered()
+	}
+}
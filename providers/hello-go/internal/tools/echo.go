@@ -1,10 +1,28 @@
 package tools
 
 import (
+	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
 )
 
+//go:embed schemas/echo.input.schema.json
+var echoInputSchema []byte
+
+func init() {
+	Register(&factory{
+		name:        "echo.v1",
+		description: "Echoes back the provided message",
+		inputSchema: echoInputSchema,
+		constructor: func(cfg json.RawMessage) (Tool, error) {
+			return &Echo{}, nil
+		},
+	})
+}
+
 // Echo implements the echo.v1 tool
 type Echo struct{}
 
@@ -28,9 +46,9 @@ func (e *Echo) Description() string {
 	return "Echoes back the provided message"
 }
 
-// Invoke executes the echo tool
+// InvokeCtx executes the echo tool.
 // Input is expected to be pre-validated against echo.input.schema.json
-func (e *Echo) Invoke(payloadBytes []byte) ([]byte, error) {
+func (e *Echo) InvokeCtx(ctx context.Context, payloadBytes []byte, tctx streaming.ToolContext) ([]byte, error) {
 	var input EchoInput
 	if err := json.Unmarshal(payloadBytes, &input); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
@@ -0,0 +1,38 @@
+//go:build linux || darwin
+
+package tools
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// LoadPlugins opens each .so file in paths and registers the ToolFactory
+// it exports under the symbol name "ToolFactory". A plugin that instead
+// registers itself from its own init() (the same way built-in tools do)
+// needs no exported symbol at all; LoadPlugins simply skips it.
+func LoadPlugins(paths []string) error {
+	for _, path := range paths {
+		p, err := plugin.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open tool plugin %s: %w", path, err)
+		}
+
+		sym, err := p.Lookup("ToolFactory")
+		if err != nil {
+			continue
+		}
+
+		// plugin.Lookup returns a pointer to the exported variable, not the
+		// variable's value, so a `var ToolFactory tools.ToolFactory` in the
+		// plugin surfaces here as *ToolFactory rather than ToolFactory.
+		f, ok := sym.(*ToolFactory)
+		if !ok {
+			return fmt.Errorf("tool plugin %s: ToolFactory symbol does not implement tools.ToolFactory", path)
+		}
+
+		Register(*f)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build linux || darwin
+
+package tools_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
+)
+
+// TestLoadPlugins_SmokeBuiltPlugin builds testdata/smokeplugin into a real
+// .so and loads it through LoadPlugins, guarding against the pointer-vs-
+// value symbol mismatch that plugin.Lookup is documented to produce for a
+// package-level `var ToolFactory tools.ToolFactory`.
+func TestLoadPlugins_SmokeBuiltPlugin(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	soPath := filepath.Join(t.TempDir(), "smoke.so")
+	build := exec.Command("go", "build", "-buildmode=plugin", "-o", soPath, "./testdata/smokeplugin")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build smoke plugin: %v\n%s", err, out)
+	}
+
+	if err := tools.LoadPlugins([]string{soPath}); err != nil {
+		t.Fatalf("LoadPlugins failed: %v", err)
+	}
+
+	for _, f := range tools.Factories() {
+		if f.Name() == "smoke.v1" {
+			return
+		}
+	}
+	t.Fatal("expected smoke.v1 factory to be registered after LoadPlugins")
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin)
+
+package tools
+
+import "fmt"
+
+// LoadPlugins is unavailable on this platform because Go's plugin package
+// only supports linux and darwin.
+func LoadPlugins(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return fmt.Errorf("tool plugin loading is not supported on this platform")
+}
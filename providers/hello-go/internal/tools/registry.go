@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+)
+
+// Tool defines the interface all tools must implement.
+type Tool interface {
+	Name() string
+	Description() string
+	// InvokeCtx executes the tool. tctx gives the tool access to
+	// request-scoped facilities, such as publishing progress events to
+	// streaming subscribers keyed by the invocation's correlation ID.
+	InvokeCtx(ctx context.Context, payload []byte, tctx streaming.ToolContext) ([]byte, error)
+}
+
+// ToolFactory describes a pluggable tool: its identity, the JSON schemas
+// it declares, and how to construct a live instance from a per-tool
+// configuration block. Built-in tools register a factory from their own
+// init(); tools loaded via LoadPlugins supply one the same way.
+type ToolFactory interface {
+	Name() string
+	Description() string
+	InputSchema() []byte
+	OutputSchema() []byte
+	Constructor() func(cfg json.RawMessage) (Tool, error)
+}
+
+// factory is the concrete ToolFactory used by every built-in tool.
+type factory struct {
+	name         string
+	description  string
+	inputSchema  []byte
+	outputSchema []byte
+	constructor  func(cfg json.RawMessage) (Tool, error)
+}
+
+func (f *factory) Name() string        { return f.name }
+func (f *factory) Description() string { return f.description }
+func (f *factory) InputSchema() []byte { return f.inputSchema }
+func (f *factory) OutputSchema() []byte {
+	return f.outputSchema
+}
+func (f *factory) Constructor() func(cfg json.RawMessage) (Tool, error) {
+	return f.constructor
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]ToolFactory{}
+)
+
+// Register adds factory to the global tool registry. It is meant to be
+// called from a tool's init() function, or from a plugin loaded by
+// LoadPlugins. Registering two factories under the same name panics,
+// since that almost always means a copy-paste mistake rather than an
+// intentional override.
+func Register(f ToolFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[f.Name()]; exists {
+		panic(fmt.Sprintf("tools: factory already registered for %q", f.Name()))
+	}
+	registry[f.Name()] = f
+}
+
+// Factories returns every registered ToolFactory, sorted by name so
+// capability listings are deterministic.
+func Factories() []ToolFactory {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]ToolFactory, len(names))
+	for i, name := range names {
+		out[i] = registry[name]
+	}
+	return out
+}
@@ -1,10 +1,28 @@
 package tools
 
 import (
+	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
 )
 
+//go:embed schemas/sum.input.schema.json
+var sumInputSchema []byte
+
+func init() {
+	Register(&factory{
+		name:        "sum.v1",
+		description: "Calculates the sum of an array of numbers",
+		inputSchema: sumInputSchema,
+		constructor: func(cfg json.RawMessage) (Tool, error) {
+			return &Sum{}, nil
+		},
+	})
+}
+
 // Sum implements the sum.v1 tool
 type Sum struct{}
 
@@ -28,9 +46,9 @@ func (s *Sum) Description() string {
 	return "Calculates the sum of an array of numbers"
 }
 
-// Invoke executes the sum tool
+// InvokeCtx executes the sum tool.
 // Input is expected to be pre-validated against sum.input.schema.json
-func (s *Sum) Invoke(payloadBytes []byte) ([]byte, error) {
+func (s *Sum) InvokeCtx(ctx context.Context, payloadBytes []byte, tctx streaming.ToolContext) ([]byte, error) {
 	var input SumInput
 	if err := json.Unmarshal(payloadBytes, &input); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
@@ -0,0 +1,42 @@
+// Package main builds a throwaway .so used only by
+// TestLoadPlugins_SmokeBuiltPlugin to exercise the exact symbol shape
+// plugin.Lookup hands back for a package-level `var ToolFactory
+// tools.ToolFactory`. It is never run as its own program.
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
+)
+
+type smokeTool struct{}
+
+func (smokeTool) Name() string        { return "smoke.v1" }
+func (smokeTool) Description() string { return "plugin smoke test tool" }
+
+func (smokeTool) InvokeCtx(ctx context.Context, payload []byte, tctx streaming.ToolContext) ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+type smokeFactory struct{}
+
+func (smokeFactory) Name() string        { return "smoke.v1" }
+func (smokeFactory) Description() string { return "plugin smoke test tool" }
+func (smokeFactory) InputSchema() []byte { return nil }
+func (smokeFactory) OutputSchema() []byte {
+	return nil
+}
+func (smokeFactory) Constructor() func(cfg json.RawMessage) (tools.Tool, error) {
+	return func(cfg json.RawMessage) (tools.Tool, error) {
+		return smokeTool{}, nil
+	}
+}
+
+// ToolFactory is the exported symbol LoadPlugins looks up. plugin.Lookup
+// hands the caller a pointer to this variable, not its value.
+var ToolFactory tools.ToolFactory = smokeFactory{}
+
+func main() {}
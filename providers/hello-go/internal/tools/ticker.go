@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+)
+
+//go:embed schemas/ticker.input.schema.json
+var tickerInputSchema []byte
+
+func init() {
+	Register(&factory{
+		name:        "ticker.v1",
+		description: "Publishes a heartbeat event every interval_ms, ticks times, on a topic keyed by the correlation ID",
+		inputSchema: tickerInputSchema,
+		constructor: func(cfg json.RawMessage) (Tool, error) {
+			return &Ticker{}, nil
+		},
+	})
+}
+
+// Ticker implements the ticker.v1 tool. It exists to exercise the
+// streaming path end to end: it publishes a heartbeat event every
+// IntervalMs on a topic keyed by the invocation's correlation ID, so a
+// concurrent Stream subscriber has something to observe.
+type Ticker struct{}
+
+// TickerInput represents the validated input for the ticker tool
+type TickerInput struct {
+	IntervalMs int `json:"interval_ms"`
+	Ticks      int `json:"ticks"`
+}
+
+// TickerOutput represents the output of the ticker tool
+type TickerOutput struct {
+	Ticks int `json:"ticks"`
+}
+
+// TickerHeartbeat is the payload published on the ticker's topic for each
+// tick.
+type TickerHeartbeat struct {
+	Tick int   `json:"tick"`
+	Unix int64 `json:"unix"`
+}
+
+// Name returns the tool name
+func (t *Ticker) Name() string {
+	return "ticker.v1"
+}
+
+// Description returns the tool description
+func (t *Ticker) Description() string {
+	return "Publishes a heartbeat event every interval_ms, ticks times, on a topic keyed by the correlation ID"
+}
+
+// TopicFor returns the topic ticker.v1 publishes heartbeats to for a given
+// correlation ID, so callers know what to pass to Stream.
+func TopicFor(correlationID string) string {
+	return "ticker.v1/" + correlationID
+}
+
+// InvokeCtx executes the ticker tool
+// Input is expected to be pre-validated against ticker.input.schema.json
+func (t *Ticker) InvokeCtx(ctx context.Context, payloadBytes []byte, tctx streaming.ToolContext) ([]byte, error) {
+	var input TickerInput
+	if err := json.Unmarshal(payloadBytes, &input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	if input.IntervalMs <= 0 {
+		input.IntervalMs = 1000
+	}
+	if input.Ticks <= 0 {
+		input.Ticks = 1
+	}
+
+	topic := TopicFor(tctx.CorrelationID)
+	ticker := time.NewTicker(time.Duration(input.IntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	sent := 0
+	for sent < input.Ticks {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case now := <-ticker.C:
+			sent++
+			if tctx.Broker != nil {
+				heartbeat, err := json.Marshal(TickerHeartbeat{Tick: sent, Unix: now.Unix()})
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal heartbeat: %w", err)
+				}
+				tctx.Broker.Publish(streaming.Event{
+					Topic:   topic,
+					Key:     tctx.CorrelationID,
+					Payload: heartbeat,
+				})
+			}
+		}
+	}
+
+	output := TickerOutput{Ticks: sent}
+	resultBytes, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	return resultBytes, nil
+}
@@ -0,0 +1,107 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/streaming"
+	"github.com/forgetrade/mcp-trader/providers/hello-go/internal/tools"
+)
+
+// TestTicker_PublishesHeartbeatsToSubscriber exercises the ticker.v1 tool
+// the way Invoke and Stream use it together: a subscriber is attached to
+// the topic InvokeCtx will publish on before the invocation starts, and
+// should observe every heartbeat plus the final result.
+func TestTicker_PublishesHeartbeatsToSubscriber(t *testing.T) {
+	broker := streaming.NewBroker()
+	correlationID := "test-correlation-id"
+
+	sub := broker.Subscribe(tools.TopicFor(correlationID), streaming.SubscriberOptions{
+		Policy:     streaming.DropOldest,
+		BufferSize: 8,
+	})
+	defer sub.Close()
+
+	ticker := &tools.Ticker{}
+	input, err := json.Marshal(tools.TickerInput{IntervalMs: 1, Ticks: 3})
+	if err != nil {
+		t.Fatalf("failed to marshal input: %v", err)
+	}
+
+	tctx := streaming.ToolContext{CorrelationID: correlationID, Broker: broker}
+
+	resultCh := make(chan []byte, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		result, err := ticker.InvokeCtx(context.Background(), input, tctx)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		resultCh <- result
+	}()
+
+	for tick := 1; tick <= 3; tick++ {
+		select {
+		case event := <-sub.Events:
+			var heartbeat tools.TickerHeartbeat
+			if err := json.Unmarshal(event.Payload, &heartbeat); err != nil {
+				t.Fatalf("failed to unmarshal heartbeat: %v", err)
+			}
+			if heartbeat.Tick != tick {
+				t.Fatalf("expected tick %d, got %d", tick, heartbeat.Tick)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for heartbeat %d", tick)
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		t.Fatalf("InvokeCtx failed: %v", err)
+	case result := <-resultCh:
+		var output tools.TickerOutput
+		if err := json.Unmarshal(result, &output); err != nil {
+			t.Fatalf("failed to unmarshal output: %v", err)
+		}
+		if output.Ticks != 3 {
+			t.Fatalf("expected 3 ticks, got %d", output.Ticks)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for InvokeCtx to return")
+	}
+}
+
+// TestTicker_CancelStopsEarly verifies a canceled context stops the ticker
+// before it reaches the requested tick count, the same way a canceled or
+// disconnected Invoke call's ctx.Done() would.
+func TestTicker_CancelStopsEarly(t *testing.T) {
+	broker := streaming.NewBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ticker := &tools.Ticker{}
+	input, err := json.Marshal(tools.TickerInput{IntervalMs: 50, Ticks: 100})
+	if err != nil {
+		t.Fatalf("failed to marshal input: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ticker.InvokeCtx(ctx, input, streaming.ToolContext{CorrelationID: "c", Broker: broker})
+		done <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected InvokeCtx to return an error after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("InvokeCtx did not return promptly after context cancellation")
+	}
+}